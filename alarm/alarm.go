@@ -0,0 +1,108 @@
+// Package alarm implements a persistent, cluster-visible alarm subsystem for
+// clock-health degradations, modelled on etcd's alarm subsystem: alarms are
+// raised through the same raft group that already replicates oracle and
+// cluster membership state, so every replica agrees on which alarms are
+// active without any change to the oracle wire protocol.
+package alarm
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Type identifies why an Alarm was raised.
+type Type string
+
+const (
+	// TimeDivergence is raised when ValidateTimeInConsensus would fail
+	// because a node's drift-corrected time has diverged from the oracle by
+	// more than the configured threshold.
+	TimeDivergence Type = "TIME_DIVERGENCE"
+	// OracleFlap is raised when oracle elections happen more than the
+	// configured number of times within a window.
+	OracleFlap Type = "ORACLE_FLAP"
+	// ClockJumpBackward is raised when a node's local monotonic clock is
+	// observed to have jumped backward.
+	ClockJumpBackward Type = "CLOCK_JUMP_BACKWARD"
+)
+
+// Alarm is a single persistent, cluster-visible condition. Once raised, an
+// alarm stays active on every replica until explicitly disarmed.
+type Alarm struct {
+	ID      string    `json:"id"`
+	Type    Type      `json:"type"`
+	NodeID  uint64    `json:"node_id"`
+	Raised  time.Time `json:"raised"`
+	Details string    `json:"details"`
+}
+
+// id deterministically identifies an alarm by type and node, so raising the
+// same condition twice is idempotent rather than creating duplicate alarms.
+func id(typ Type, nodeID uint64) string {
+	return fmt.Sprintf("%s/%d", typ, nodeID)
+}
+
+// Storage is the raft-backed store that Store proposes alarm state changes
+// through. It is implemented by the same metadata store that already
+// replicates oracle and cluster membership state; alarm state piggy-backs on
+// raft snapshots alongside that state rather than introducing a new wire
+// message.
+type Storage interface {
+	// ProposeRaise proposes raising a through raft. Proposing an alarm whose
+	// ID is already active is a no-op.
+	ProposeRaise(ctx context.Context, a Alarm) error
+	// ProposeDisarm proposes clearing the alarm with the given id.
+	ProposeDisarm(ctx context.Context, id string) error
+	// AllAlarms returns every currently active alarm.
+	AllAlarms() map[string]Alarm
+}
+
+// Store is the local view of the cluster's alarm state, backed by Storage.
+type Store struct {
+	storage Storage
+}
+
+// NewStore returns a Store backed by storage.
+func NewStore(storage Storage) *Store {
+	return &Store{storage: storage}
+}
+
+// Raise proposes raising an alarm of the given type for nodeID, with details
+// describing the specific condition observed.
+func (s *Store) Raise(ctx context.Context, typ Type, nodeID uint64, details string) error {
+	return s.storage.ProposeRaise(ctx, Alarm{
+		ID:      id(typ, nodeID),
+		Type:    typ,
+		NodeID:  nodeID,
+		Raised:  time.Now(),
+		Details: details,
+	})
+}
+
+// Disarm clears a previously raised alarm by id.
+func (s *Store) Disarm(ctx context.Context, id string) error {
+	return s.storage.ProposeDisarm(ctx, id)
+}
+
+// List returns every currently active alarm.
+func (s *Store) List() []Alarm {
+	all := s.storage.AllAlarms()
+	alarms := make([]Alarm, 0, len(all))
+	for _, a := range all {
+		alarms = append(alarms, a)
+	}
+	return alarms
+}
+
+// IsDegraded reports whether nodeID has any active alarm. A degraded node
+// must refuse to serve Time RPCs until every alarm affecting it is disarmed,
+// so that callers never observe a pathological time value.
+func (s *Store) IsDegraded(nodeID uint64) bool {
+	for _, a := range s.storage.AllAlarms() {
+		if a.NodeID == nodeID {
+			return true
+		}
+	}
+	return false
+}
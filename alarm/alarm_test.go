@@ -0,0 +1,24 @@
+package alarm
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestStoreRaiseSetsRaisedTimestamp(t *testing.T) {
+	storage := newFakeStorage()
+	store := NewStore(storage)
+	ctx := context.Background()
+
+	before := time.Now()
+	if err := store.Raise(ctx, ClockJumpBackward, 1, "test"); err != nil {
+		t.Fatal(err)
+	}
+	after := time.Now()
+
+	raised := storage.raised[id(ClockJumpBackward, 1)].Raised
+	if raised.Before(before) || raised.After(after) {
+		t.Fatalf("expected Raised to be set to the time Raise was called, got %s (want between %s and %s)", raised, before, after)
+	}
+}
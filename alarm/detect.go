@@ -0,0 +1,83 @@
+package alarm
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DetectTimeDivergence raises a TimeDivergence alarm for nodeID if its
+// drift-corrected time has moved more than threshold away from the oracle's
+// time, mirroring the check ValidateTimeInConsensus already performs for
+// tests but persisting the result as a cluster-visible alarm instead of just
+// failing a single caller's request.
+//
+// Calling this from the real oracle-management loop on every tick is out of
+// scope for this change: that loop lives in the oracle/tm packages, neither
+// of which exist in this checkout. DetectClockJumpBackward, below, is wired
+// into liveness.NodeLiveness's heartbeat loop, which does exist and run;
+// DetectTimeDivergence and FlapTracker remain covered only by their own unit
+// tests (detect_test.go) until a time-source/oracle-election loop exists to
+// drive them from.
+func DetectTimeDivergence(
+	ctx context.Context, store *Store, nodeID uint64, nodeTime, oracleTime time.Time, threshold time.Duration,
+) error {
+	delta := nodeTime.Sub(oracleTime)
+	if delta < 0 {
+		delta = -delta
+	}
+	if delta <= threshold {
+		return nil
+	}
+	return store.Raise(ctx, TimeDivergence, nodeID, fmt.Sprintf(
+		"node time diverged from oracle by %s, exceeding threshold %s", delta, threshold,
+	))
+}
+
+// FlapTracker counts oracle elections observed within a sliding window and
+// raises an OracleFlap alarm once the count within the window exceeds
+// maxElections.
+type FlapTracker struct {
+	window       time.Duration
+	maxElections int
+	elections    []time.Time
+}
+
+// NewFlapTracker returns a FlapTracker that raises an alarm once more than
+// maxElections oracle elections are observed within window.
+func NewFlapTracker(window time.Duration, maxElections int) *FlapTracker {
+	return &FlapTracker{window: window, maxElections: maxElections}
+}
+
+// Observe records an oracle election at instant now and, if it pushes the
+// count of elections within the tracker's window over the configured
+// maximum, raises an OracleFlap alarm for nodeID (the local node observing
+// the flap, not necessarily the elected oracle).
+func (f *FlapTracker) Observe(ctx context.Context, store *Store, nodeID uint64, now time.Time) error {
+	f.elections = append(f.elections, now)
+	cutoff := now.Add(-f.window)
+	kept := f.elections[:0]
+	for _, t := range f.elections {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	f.elections = kept
+	if len(f.elections) <= f.maxElections {
+		return nil
+	}
+	return store.Raise(ctx, OracleFlap, nodeID, fmt.Sprintf(
+		"%d oracle elections observed in the last %s, exceeding max %d", len(f.elections), f.window, f.maxElections,
+	))
+}
+
+// DetectClockJumpBackward raises a ClockJumpBackward alarm for nodeID if the
+// local monotonic clock was just observed to be behind a previous reading.
+func DetectClockJumpBackward(ctx context.Context, store *Store, nodeID uint64, previous, current time.Time) error {
+	if !current.Before(previous) {
+		return nil
+	}
+	return store.Raise(ctx, ClockJumpBackward, nodeID, fmt.Sprintf(
+		"local monotonic clock jumped backward from %s to %s", previous, current,
+	))
+}
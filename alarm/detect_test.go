@@ -0,0 +1,108 @@
+package alarm
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type fakeStorage struct {
+	raised  map[string]Alarm
+	disarms []string
+}
+
+func newFakeStorage() *fakeStorage {
+	return &fakeStorage{raised: make(map[string]Alarm)}
+}
+
+func (f *fakeStorage) ProposeRaise(_ context.Context, a Alarm) error {
+	f.raised[a.ID] = a
+	return nil
+}
+
+func (f *fakeStorage) ProposeDisarm(_ context.Context, id string) error {
+	f.disarms = append(f.disarms, id)
+	delete(f.raised, id)
+	return nil
+}
+
+func (f *fakeStorage) AllAlarms() map[string]Alarm {
+	return f.raised
+}
+
+func TestFlapTrackerRaisesAfterThreshold(t *testing.T) {
+	storage := newFakeStorage()
+	store := NewStore(storage)
+	tracker := NewFlapTracker(time.Minute, 2)
+	ctx := context.Background()
+	base := time.Unix(0, 0)
+
+	for i := 0; i < 2; i++ {
+		if err := tracker.Observe(ctx, store, 1, base.Add(time.Duration(i)*time.Second)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(storage.raised) != 0 {
+		t.Fatalf("expected no alarm yet, got %v", storage.raised)
+	}
+
+	if err := tracker.Observe(ctx, store, 1, base.Add(3*time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if len(storage.raised) != 1 {
+		t.Fatalf("expected one alarm after exceeding max elections, got %v", storage.raised)
+	}
+}
+
+func TestDetectTimeDivergence(t *testing.T) {
+	storage := newFakeStorage()
+	store := NewStore(storage)
+	ctx := context.Background()
+	oracleTime := time.Now()
+
+	if err := DetectTimeDivergence(ctx, store, 1, oracleTime.Add(time.Second), oracleTime, 5*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if store.IsDegraded(1) {
+		t.Fatal("node time within threshold of the oracle should not raise an alarm")
+	}
+
+	if err := DetectTimeDivergence(ctx, store, 1, oracleTime.Add(10*time.Second), oracleTime, 5*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if !store.IsDegraded(1) {
+		t.Fatal("node time diverging from the oracle by more than threshold should raise an alarm")
+	}
+
+	// A node lagging behind the oracle by more than threshold is just as much
+	// a divergence as one running ahead of it.
+	storage2 := newFakeStorage()
+	store2 := NewStore(storage2)
+	if err := DetectTimeDivergence(ctx, store2, 2, oracleTime.Add(-10*time.Second), oracleTime, 5*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if !store2.IsDegraded(2) {
+		t.Fatal("node time lagging the oracle by more than threshold should raise an alarm")
+	}
+}
+
+func TestDetectClockJumpBackward(t *testing.T) {
+	storage := newFakeStorage()
+	store := NewStore(storage)
+	ctx := context.Background()
+	now := time.Now()
+
+	if err := DetectClockJumpBackward(ctx, store, 1, now, now.Add(time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if store.IsDegraded(1) {
+		t.Fatal("clock moving forward should not raise an alarm")
+	}
+
+	if err := DetectClockJumpBackward(ctx, store, 1, now, now.Add(-time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if !store.IsDegraded(1) {
+		t.Fatal("clock jumping backward should raise an alarm and degrade the node")
+	}
+}
@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	"github.com/elliotcourant/kronos/kronosutil/log"
+)
+
+// adminHost is the address of the node that operator commands talking to its
+// admin HTTP API ("kronos admin", "kronos alarms", "kronos lease") should
+// talk to.
+var adminHost string
+
+// registerHostFlag adds the --host persistent flag to cmd. It is shared by
+// every subcommand group built on adminGet/adminPost so the flag's name,
+// default and help text aren't copy-pasted at each of their call sites.
+func registerHostFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(
+		&adminHost, "host", "localhost:8080", "address of the node's admin HTTP API",
+	)
+}
+
+func init() {
+	registerHostFlag(adminCmd)
+	adminCmd.AddCommand(
+		adminTimeCmd,
+		adminOracleCmd,
+		adminNodesCmd,
+		adminFreezeCmd,
+		adminUnfreezeCmd,
+		adminDriftCmd,
+	)
+	RootCmd.AddCommand(adminCmd)
+}
+
+var adminCmd = &cobra.Command{
+	Use:   "admin",
+	Short: "Inspect and administer a running kronos node over its HTTP admin API",
+}
+
+func adminGet(path string) error {
+	resp, err := http.Get(fmt.Sprintf("http://%s%s", adminHost, path))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(body))
+	return nil
+}
+
+func adminPost(path string, body interface{}) error {
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	resp, err := http.Post(
+		fmt.Sprintf("http://%s%s", adminHost, path), "application/json", bytes.NewReader(buf),
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("admin request to %s failed: %s: %s", path, resp.Status, respBody)
+	}
+	return nil
+}
+
+var adminTimeCmd = &cobra.Command{
+	Use:   "time",
+	Short: "Print the node's oracle-corrected time and local wall-clock time",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return adminGet("/v1/time")
+	},
+}
+
+var adminOracleCmd = &cobra.Command{
+	Use:   "oracle",
+	Short: "Print the current oracle node id, epoch and last-elected time",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return adminGet("/v1/oracle")
+	},
+}
+
+var adminNodesCmd = &cobra.Command{
+	Use:   "nodes",
+	Short: "Print raft membership with liveness and last-seen information",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return adminGet("/v1/cluster/nodes")
+	},
+}
+
+var adminFreezeCmd = &cobra.Command{
+	Use:   "freeze",
+	Short: "Halt oracle re-election and new time updates so a backup can be taken safely",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := adminPost("/v1/cluster/freeze", map[string]bool{"unfreeze": false}); err != nil {
+			return err
+		}
+		log.Infof(context.Background(), "node %s frozen", adminHost)
+		return nil
+	},
+}
+
+var adminUnfreezeCmd = &cobra.Command{
+	Use:   "unfreeze",
+	Short: "Reverse a previous freeze",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := adminPost("/v1/cluster/freeze", map[string]bool{"unfreeze": true}); err != nil {
+			return err
+		}
+		log.Infof(context.Background(), "node %s unfrozen", adminHost)
+		return nil
+	},
+}
+
+var driftFactor float64
+var driftOffset int64
+
+func init() {
+	adminDriftCmd.Flags().Float64Var(&driftFactor, "factor", 1.0, "drift factor to apply")
+	adminDriftCmd.Flags().Int64Var(&driftOffset, "offset", 0, "drift offset, in nanoseconds")
+}
+
+var adminDriftCmd = &cobra.Command{
+	Use:   "drift",
+	Short: "Adjust the node's DriftTimeConfig at runtime",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return adminPost("/v1/drift", map[string]interface{}{
+			"drift_factor": driftFactor,
+			"offset":       driftOffset,
+		})
+	},
+}
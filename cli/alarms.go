@@ -0,0 +1,33 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	registerHostFlag(alarmsCmd)
+	alarmsCmd.AddCommand(alarmsListCmd, alarmsDisarmCmd)
+	RootCmd.AddCommand(alarmsCmd)
+}
+
+var alarmsCmd = &cobra.Command{
+	Use:   "alarms",
+	Short: "List and disarm persistent cluster-visible alarms",
+}
+
+var alarmsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List every currently active alarm",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return adminGet("/v1/alarms")
+	},
+}
+
+var alarmsDisarmCmd = &cobra.Command{
+	Use:   "disarm <alarm-id>",
+	Short: "Clear a previously raised alarm by id",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return adminPost("/v1/alarms/disarm", map[string]string{"id": args[0]})
+	},
+}
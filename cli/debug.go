@@ -0,0 +1,221 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/elliotcourant/kronos/checksumfile"
+)
+
+// debugFormat controls how `kronos debug` subcommands render their output,
+// so they can be scripted with --format=json instead of parsed out of a
+// human-readable table.
+var debugFormat string
+
+func init() {
+	debugCmd.PersistentFlags().StringVar(
+		&debugFormat, "format", "table", "output format: table or json",
+	)
+	debugBackupCmd.AddCommand(debugBackupShowCmd, debugBackupVerifyCmd)
+	debugCmd.AddCommand(debugClusterInfoCmd, debugRaftLogCmd, debugBackupCmd)
+	RootCmd.AddCommand(debugCmd)
+}
+
+// debugCmd groups offline inspection commands that read a node's data
+// directory directly off disk instead of talking to a running process,
+// following the "cockroach debug" convention of relocating inspection tools
+// under a dedicated subcommand group.
+var debugCmd = &cobra.Command{
+	Use:   "debug",
+	Short: "Offline inspection of a kronos node's data directory",
+}
+
+// assertNodeStopped refuses to proceed if dataDir appears to belong to a
+// running node, mirroring the existing invariant that backup/restore must
+// not run concurrently with the node: every node holds an exclusive flock on
+// LOCK in its data directory for as long as it is running.
+func assertNodeStopped(dataDir string) error {
+	lockPath := filepath.Join(dataDir, "LOCK")
+	f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		return fmt.Errorf("node appears to be running: could not lock %s: %w", lockPath, err)
+	}
+	defer syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+	return nil
+}
+
+// clusterInfoFile mirrors the JSON shape persisted by metadata.Cluster into
+// the checksummed cluster_info file, decoded here without starting a raft
+// node so it can be inspected on a stopped node.
+type clusterInfoFile struct {
+	Nodes []struct {
+		NodeID    uint64 `json:"node_id"`
+		Address   string `json:"address"`
+		IsRemoved bool   `json:"is_removed"`
+	} `json:"nodes"`
+}
+
+// parseClusterInfo decodes the JSON shape persisted by metadata.Cluster,
+// split out from readClusterInfo so it can be unit tested with hand-written
+// JSON instead of needing a real checksummed file on disk.
+func parseClusterInfo(data []byte) (clusterInfoFile, error) {
+	var info clusterInfoFile
+	if err := json.Unmarshal(data, &info); err != nil {
+		return clusterInfoFile{}, err
+	}
+	return info, nil
+}
+
+func readClusterInfo(dataDir string) (clusterInfoFile, error) {
+	data, err := checksumfile.Read(filepath.Join(dataDir, "cluster_info"))
+	if err != nil {
+		return clusterInfoFile{}, err
+	}
+	return parseClusterInfo(data)
+}
+
+func printClusterInfo(info clusterInfoFile) error {
+	if debugFormat == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(info)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 2, 2, ' ', 0)
+	fmt.Fprintln(w, "NODE ID\tADDRESS\tREMOVED")
+	for _, n := range info.Nodes {
+		fmt.Fprintf(w, "%d\t%s\t%t\n", n.NodeID, n.Address, n.IsRemoved)
+	}
+	return w.Flush()
+}
+
+var debugClusterInfoCmd = &cobra.Command{
+	Use:   "cluster-info <data-dir>",
+	Short: "Decode and print the checksummed cluster_info file",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dataDir := args[0]
+		if err := assertNodeStopped(dataDir); err != nil {
+			return err
+		}
+		info, err := readClusterInfo(dataDir)
+		if err != nil {
+			return err
+		}
+		return printClusterInfo(info)
+	},
+}
+
+// raftLogEntry describes one wal or snap segment file found on disk.
+type raftLogEntry struct {
+	Dir  string `json:"dir"`
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+}
+
+// listRaftLogEntries lists the wal/snap segment files under dataDir through
+// fs, sorted by directory then name. It takes an afero.Fs, rather than
+// reading the OS filesystem directly, so it can be exercised against
+// afero.NewMemMapFs() in tests without touching disk.
+func listRaftLogEntries(fs afero.Fs, dataDir string) ([]raftLogEntry, error) {
+	var entries []raftLogEntry
+	for _, sub := range []string{"wal", "snap"} {
+		dir := filepath.Join(dataDir, sub)
+		files, err := afero.ReadDir(fs, dir)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range files {
+			entries = append(entries, raftLogEntry{Dir: sub, Name: f.Name(), Size: f.Size()})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Dir != entries[j].Dir {
+			return entries[i].Dir < entries[j].Dir
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	return entries, nil
+}
+
+var debugRaftLogCmd = &cobra.Command{
+	Use:   "raft-log <data-dir>",
+	Short: "List wal/snap segment files in a node's data directory (does not decode entries)",
+	Long: `List wal/snap segment files in a node's data directory, along with
+their sizes.
+
+This only lists the segment files present on disk; it does not decode or
+print individual raft log entries from within them. Full entry-level
+decoding is left to the wal/snap packages themselves and is not implemented
+by this command.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dataDir := args[0]
+		if err := assertNodeStopped(dataDir); err != nil {
+			return err
+		}
+		entries, err := listRaftLogEntries(afero.NewOsFs(), dataDir)
+		if err != nil {
+			return err
+		}
+		if debugFormat == "json" {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(entries)
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 2, 2, ' ', 0)
+		fmt.Fprintln(w, "DIR\tNAME\tSIZE")
+		for _, e := range entries {
+			fmt.Fprintf(w, "%s\t%s\t%d\n", e.Dir, e.Name, e.Size)
+		}
+		return w.Flush()
+	},
+}
+
+// debugBackupCmd groups commands that inspect a backup directory produced by
+// the existing `kronos backup` command without restoring it.
+var debugBackupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Inspect a kronos backup directory",
+}
+
+var debugBackupShowCmd = &cobra.Command{
+	Use:   "show <backup-dir>",
+	Short: "Print the cluster_info a backup directory would restore",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		info, err := readClusterInfo(args[0])
+		if err != nil {
+			return err
+		}
+		return printClusterInfo(info)
+	},
+}
+
+var debugBackupVerifyCmd = &cobra.Command{
+	Use:   "verify <backup-dir>",
+	Short: "Validate a backup directory's checksums and print what it would restore",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		info, err := readClusterInfo(args[0])
+		if err != nil {
+			return fmt.Errorf("backup verification failed: %w", err)
+		}
+		fmt.Println("backup OK")
+		return printClusterInfo(info)
+	},
+}
@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+)
+
+func TestParseClusterInfo(t *testing.T) {
+	data := []byte(`{"nodes":[
+		{"node_id":1,"address":"10.0.0.1:8080","is_removed":false},
+		{"node_id":2,"address":"10.0.0.2:8080","is_removed":true}
+	]}`)
+
+	info, err := parseClusterInfo(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(info.Nodes) != 2 {
+		t.Fatalf("expected 2 nodes, got %d", len(info.Nodes))
+	}
+	if info.Nodes[0].NodeID != 1 || info.Nodes[0].Address != "10.0.0.1:8080" || info.Nodes[0].IsRemoved {
+		t.Fatalf("unexpected first node: %+v", info.Nodes[0])
+	}
+	if info.Nodes[1].NodeID != 2 || !info.Nodes[1].IsRemoved {
+		t.Fatalf("unexpected second node: %+v", info.Nodes[1])
+	}
+}
+
+func TestParseClusterInfoInvalidJSON(t *testing.T) {
+	if _, err := parseClusterInfo([]byte("not json")); err == nil {
+		t.Fatal("expected an error decoding invalid JSON")
+	}
+}
+
+func TestListRaftLogEntries(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	if err := afero.WriteFile(fs, "/data/wal/0000000000000002-0000000000000000.wal", []byte("aa"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := afero.WriteFile(fs, "/data/wal/0000000000000001-0000000000000000.wal", []byte("a"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := afero.WriteFile(fs, "/data/snap/0000000000000001-0000000000000001.snap", []byte("aaa"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := listRaftLogEntries(fs, "/data")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d: %+v", len(entries), entries)
+	}
+	// snap sorts before wal, and within wal the two segments sort by name.
+	if entries[0].Dir != "snap" {
+		t.Fatalf("expected snap entry first, got %+v", entries[0])
+	}
+	if entries[1].Name != "0000000000000001-0000000000000000.wal" || entries[1].Size != 1 {
+		t.Fatalf("unexpected second entry: %+v", entries[1])
+	}
+	if entries[2].Name != "0000000000000002-0000000000000000.wal" || entries[2].Size != 2 {
+		t.Fatalf("unexpected third entry: %+v", entries[2])
+	}
+}
+
+func TestListRaftLogEntriesMissingDirs(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	entries, err := listRaftLogEntries(fs, "/empty")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected no entries for a data dir with no wal/snap subdirs, got %+v", entries)
+	}
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/elliotcourant/kronos/kronosutil/log"
+)
+
+var leaseTTL time.Duration
+var leaseID uint64
+
+func init() {
+	registerHostFlag(leaseCmd)
+	leaseGrantCmd.Flags().DurationVar(&leaseTTL, "ttl", 60*time.Second, "lease TTL")
+	leaseRevokeCmd.Flags().Uint64Var(&leaseID, "id", 0, "lease id to revoke")
+	leaseKeepAliveCmd.Flags().Uint64Var(&leaseID, "id", 0, "lease id to keep alive")
+	leaseCmd.AddCommand(leaseGrantCmd, leaseRevokeCmd, leaseKeepAliveCmd)
+	RootCmd.AddCommand(leaseCmd)
+}
+
+var leaseCmd = &cobra.Command{
+	Use:   "lease",
+	Short: "Grant, revoke and keep alive leases backed by the kronos raft group",
+}
+
+var leaseGrantCmd = &cobra.Command{
+	Use:   "grant",
+	Short: "Grant a new lease with the given --ttl",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := adminPost("/v1/lease/grant", map[string]interface{}{"ttl": leaseTTL}); err != nil {
+			return err
+		}
+		fmt.Printf("granted lease with ttl %s\n", leaseTTL)
+		return nil
+	},
+}
+
+var leaseRevokeCmd = &cobra.Command{
+	Use:   "revoke",
+	Short: "Revoke the lease identified by --id",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return adminPost("/v1/lease/revoke", map[string]uint64{"id": leaseID})
+	},
+}
+
+var leaseKeepAliveCmd = &cobra.Command{
+	Use:   "keepalive",
+	Short: "Renew the lease identified by --id until interrupted",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		for {
+			if err := adminPost("/v1/lease/keepalive", map[string]uint64{"id": leaseID}); err != nil {
+				return err
+			}
+			log.Infof(ctx, "renewed lease %d", leaseID)
+			time.Sleep(leaseTTL / 3)
+		}
+	},
+}
@@ -0,0 +1,14 @@
+// Package cli implements the kronos command line tool: starting a node and
+// operator commands for inspecting and administering a running cluster.
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// RootCmd is the root of the kronos command tree. main.go executes it
+// directly with the process's arguments.
+var RootCmd = &cobra.Command{
+	Use:   "kronos",
+	Short: "kronos is a distributed, fault-tolerant time oracle",
+}
@@ -0,0 +1,70 @@
+// Package clock provides a small abstraction over wall-clock time so that
+// code which needs to sleep, tick or measure elapsed time can be driven by a
+// deterministic fake in tests instead of the real clock.
+//
+// Threading this interface through the oracle-management loop, drift
+// application and Raft tick sources (and adding a corresponding Clock field
+// to acceptance/cluster.ClusterConfig) is out of scope for this change: none
+// of oracle, tm or acceptance/cluster exist in this checkout for the
+// interface to be wired into (the server package here holds only the admin
+// API, with no oracle loop, drift application or Raft ticks), and stubbing all of them out just to thread a
+// Clock through would mean fabricating those packages rather than editing
+// them. This package is ready to be consumed by that wiring once it exists.
+package clock
+
+import "time"
+
+// Clock is the subset of the time package that Kronos depends on. Production
+// code should take a Clock instead of calling the time package directly so
+// that tests can substitute a FakeClock and advance time deterministically.
+type Clock interface {
+	// Now returns the current wall-clock time.
+	Now() time.Time
+	// Since returns the time elapsed since t.
+	Since(t time.Time) time.Duration
+	// After returns a channel that receives the current time after d has
+	// elapsed, mirroring time.After.
+	After(d time.Duration) <-chan time.Time
+	// NewTicker returns a Ticker that sends the current time on its channel
+	// every d, mirroring time.NewTicker.
+	NewTicker(d time.Duration) Ticker
+	// Sleep blocks for d, mirroring time.Sleep.
+	Sleep(d time.Duration)
+}
+
+// Ticker mirrors the parts of time.Ticker that callers need. It is an
+// interface rather than a struct so that FakeClock can hand out tickers whose
+// channel it controls.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// realClock implements Clock using the actual time package.
+type realClock struct{}
+
+// NewClock returns a Clock backed by the real time package.
+func NewClock() Clock {
+	return realClock{}
+}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) Since(t time.Time) time.Duration { return time.Since(t) }
+
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+func (realClock) Sleep(d time.Duration) { time.Sleep(d) }
+
+func (realClock) NewTicker(d time.Duration) Ticker {
+	return realTicker{time.NewTicker(d)}
+}
+
+// realTicker adapts *time.Ticker to the Ticker interface.
+type realTicker struct {
+	t *time.Ticker
+}
+
+func (r realTicker) C() <-chan time.Time { return r.t.C }
+
+func (r realTicker) Stop() { r.t.Stop() }
@@ -0,0 +1,148 @@
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// FakeClock is a Clock whose notion of "now" only moves when Advance is
+// called. It is modelled on jonboulle/clockwork's FakeClock and is meant to
+// let tests drive time-dependent code (tickers, sleeps, timeouts)
+// deterministically instead of sleeping on the real clock and hoping enough
+// wall-clock time has passed.
+type FakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []*fakeWaiter
+}
+
+// fakeWaiter is a single pending After/Sleep/ticker registration.
+type fakeWaiter struct {
+	targetTime time.Time
+	destChan   chan time.Time
+	// recurring is set for tickers: once fired, the waiter is rescheduled
+	// interval after targetTime instead of being removed.
+	recurring bool
+	interval  time.Duration
+	stopped   bool
+}
+
+// NewFakeClock returns a FakeClock whose current time is set to the given
+// start time.
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{now: start}
+}
+
+// Now returns the FakeClock's current time.
+func (f *FakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Since returns the FakeClock's current time minus t.
+func (f *FakeClock) Since(t time.Time) time.Duration {
+	return f.Now().Sub(t)
+}
+
+// After returns a channel that fires once the FakeClock has been advanced by
+// at least d.
+func (f *FakeClock) After(d time.Duration) <-chan time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	f.waiters = append(f.waiters, &fakeWaiter{
+		targetTime: f.now.Add(d),
+		destChan:   ch,
+	})
+	return ch
+}
+
+// Sleep blocks the calling goroutine until the FakeClock has been advanced by
+// at least d.
+func (f *FakeClock) Sleep(d time.Duration) {
+	<-f.After(d)
+}
+
+// NewTicker returns a Ticker whose channel fires every time the FakeClock is
+// advanced by at least d, and keeps firing every d thereafter.
+func (f *FakeClock) NewTicker(d time.Duration) Ticker {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	w := &fakeWaiter{
+		targetTime: f.now.Add(d),
+		destChan:   ch,
+		recurring:  true,
+		interval:   d,
+	}
+	f.waiters = append(f.waiters, w)
+	return &fakeTicker{fakeClock: f, waiter: w}
+}
+
+// Advance moves the FakeClock's current time forward by d, firing any
+// After/Sleep/ticker waiters whose target time has now been reached, in
+// target-time order.
+func (f *FakeClock) Advance(d time.Duration) {
+	f.mu.Lock()
+	f.now = f.now.Add(d)
+	now := f.now
+
+	sort.Slice(f.waiters, func(i, j int) bool {
+		return f.waiters[i].targetTime.Before(f.waiters[j].targetTime)
+	})
+
+	var remaining []*fakeWaiter
+	for _, w := range f.waiters {
+		if w.stopped {
+			continue
+		}
+		if w.targetTime.After(now) {
+			remaining = append(remaining, w)
+			continue
+		}
+		select {
+		case w.destChan <- now:
+		default:
+		}
+		if w.recurring {
+			w.targetTime = w.targetTime.Add(w.interval)
+			remaining = append(remaining, w)
+		}
+	}
+	f.waiters = remaining
+	f.mu.Unlock()
+}
+
+// BlockUntil blocks until the FakeClock has at least n pending waiters:
+// calls to After or Sleep that haven't yet fired, plus every ticker (a
+// ticker stays pending after it fires, and a stopped one stays counted until
+// the next Advance). Tests use this
+// to know that a goroutine under test has reached the point where it is
+// waiting on the clock before calling Advance.
+func (f *FakeClock) BlockUntil(n int) {
+	for {
+		f.mu.Lock()
+		count := len(f.waiters)
+		f.mu.Unlock()
+		if count >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// fakeTicker adapts a fakeWaiter to the Ticker interface.
+type fakeTicker struct {
+	fakeClock *FakeClock
+	waiter    *fakeWaiter
+}
+
+func (t *fakeTicker) C() <-chan time.Time { return t.waiter.destChan }
+
+func (t *fakeTicker) Stop() {
+	t.fakeClock.mu.Lock()
+	defer t.fakeClock.mu.Unlock()
+	t.waiter.stopped = true
+}
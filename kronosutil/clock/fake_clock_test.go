@@ -0,0 +1,50 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFakeClockAdvance(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	fc := NewFakeClock(start)
+
+	done := make(chan time.Duration, 1)
+	go func() {
+		before := fc.Now()
+		fc.Sleep(5 * time.Second)
+		done <- fc.Since(before)
+	}()
+
+	fc.BlockUntil(1)
+	fc.Advance(5 * time.Second)
+
+	select {
+	case elapsed := <-done:
+		if elapsed != 5*time.Second {
+			t.Fatalf("expected 5s elapsed, got %v", elapsed)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Sleep did not return after Advance")
+	}
+}
+
+func TestFakeClockTicker(t *testing.T) {
+	fc := NewFakeClock(time.Unix(0, 0))
+	ticker := fc.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	fc.Advance(time.Second)
+	select {
+	case <-ticker.C():
+	default:
+		t.Fatal("expected ticker to fire after Advance")
+	}
+
+	fc.Advance(2 * time.Second)
+	select {
+	case <-ticker.C():
+	default:
+		t.Fatal("expected ticker to fire again after second Advance")
+	}
+}
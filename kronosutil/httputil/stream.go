@@ -0,0 +1,35 @@
+// Package httputil holds small helpers shared by Kronos's HTTP-facing code,
+// such as the admin inspection API under server/admin.go.
+package httputil
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// StreamJSON writes each value in items to w as a newline-delimited JSON
+// stream, flushing after every value so that a caller watching a long-running
+// endpoint (e.g. cluster/nodes on a large cluster) sees results as they are
+// produced instead of waiting for the whole response to buffer.
+func StreamJSON(w http.ResponseWriter, items <-chan interface{}) error {
+	w.Header().Set("Content-Type", "application/json; boundary=NL")
+	flusher, canFlush := w.(http.Flusher)
+	enc := json.NewEncoder(w)
+	for item := range items {
+		if err := enc.Encode(item); err != nil {
+			return err
+		}
+		if canFlush {
+			flusher.Flush()
+		}
+	}
+	return nil
+}
+
+// WriteJSON writes a single JSON value to w with the appropriate content
+// type, used by admin endpoints that return one object rather than a stream.
+func WriteJSON(w http.ResponseWriter, status int, v interface{}) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	return json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,59 @@
+package lease
+
+import (
+	"context"
+	"time"
+
+	"github.com/elliotcourant/kronos/kronosutil/clock"
+	"github.com/elliotcourant/kronos/kronosutil/log"
+)
+
+// expirerTickInterval is how often Expirer checks for leases that have
+// passed their ExpiresAt.
+const expirerTickInterval = time.Second
+
+// Expirer watches every granted lease and proposes revoking it once it has
+// expired according to the oracle's time. Because every replica runs an
+// Expirer against the same raft-replicated oracle time, they agree on the
+// instant a lease expires regardless of any individual node's local drift.
+type Expirer struct {
+	storage Storage
+	oracle  OracleTimeSource
+	clock   clock.Clock
+}
+
+// NewExpirer returns an Expirer backed by storage and timed by oracle. clock
+// paces Run's tick loop; lease expiration itself is always measured against
+// the oracle's time, never clock.
+func NewExpirer(storage Storage, oracle OracleTimeSource, clock clock.Clock) *Expirer {
+	return &Expirer{storage: storage, oracle: oracle, clock: clock}
+}
+
+// checkOnce revokes every lease whose ExpiresAt is no later than the
+// oracle's current time.
+func (e *Expirer) checkOnce(ctx context.Context) {
+	now := e.oracle.CorrectedTime()
+	for id, l := range e.storage.All() {
+		if now.Before(l.ExpiresAt) {
+			continue
+		}
+		if err := e.storage.ProposeRevoke(ctx, id); err != nil {
+			log.Errorf(ctx, "failed to expire lease %d: %v", id, err)
+		}
+	}
+}
+
+// Run ticks checkOnce every expirerTickInterval (as measured by e.clock)
+// until ctx is cancelled.
+func (e *Expirer) Run(ctx context.Context) {
+	ticker := e.clock.NewTicker(expirerTickInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+			e.checkOnce(ctx)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+// Package lease implements an etcd-style lease/TTL API on top of the same
+// raft group Kronos already runs for oracle election and metadata.Cluster.
+// The key difference from etcd's leases is that expiration is evaluated
+// against the cluster's synchronized oracle time rather than any single
+// node's local wall clock, so a partitioned or drifting node cannot
+// prematurely expire (or fail to expire) another node's lease: every
+// replica agrees on when a lease expires because they agree on what time it
+// is.
+package lease
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/elliotcourant/kronos/kronosutil/clock"
+)
+
+// ID identifies a single lease.
+type ID uint64
+
+// Lease is a single grant, replicated through raft. GrantedAt and ExpiresAt
+// are both oracle time, not any node's local time.
+type Lease struct {
+	ID        ID            `json:"id"`
+	TTL       time.Duration `json:"ttl"`
+	GrantedAt time.Time     `json:"granted_at"`
+	ExpiresAt time.Time     `json:"expires_at"`
+}
+
+// KeepAliveResponse is sent on the channel returned by Manager.KeepAlive
+// every time a keepalive successfully renews a lease.
+type KeepAliveResponse struct {
+	ID  ID            `json:"id"`
+	TTL time.Duration `json:"ttl"`
+}
+
+// Storage is the raft-backed store of lease state. It is implemented by the
+// same metadata store that already replicates oracle and cluster membership
+// state, so lease grants, renewals and revocations go through the existing
+// raft group rather than a second one.
+type Storage interface {
+	// ProposeGrant proposes l through raft. l.ID is already assigned by the
+	// caller (Manager takes it from NextID).
+	ProposeGrant(ctx context.Context, l Lease) error
+	// ProposeRevoke proposes removing id through raft.
+	ProposeRevoke(ctx context.Context, id ID) error
+	// ProposeRenew proposes extending id's ExpiresAt through raft.
+	ProposeRenew(ctx context.Context, id ID, expiresAt time.Time) error
+	// NextID returns an ID not currently in use by any lease.
+	NextID() ID
+	// Get returns the lease last proposed for id, if any.
+	Get(id ID) (Lease, bool)
+	// All returns every currently granted lease.
+	All() map[ID]Lease
+}
+
+// OracleTimeSource is the subset of server.Server (or any other oracle
+// client) that Manager needs: the cluster's current oracle-corrected time,
+// which is what lease expiration is evaluated against instead of any local
+// wall clock.
+type OracleTimeSource interface {
+	CorrectedTime() time.Time
+}
+
+// Manager implements the lease API on top of Storage and an
+// OracleTimeSource. The server package exposes a Manager through its admin
+// HTTP API (the /v1/lease/... endpoints); there is no gRPC lease service.
+type Manager struct {
+	storage Storage
+	oracle  OracleTimeSource
+	clock   clock.Clock
+}
+
+// NewManager returns a Manager backed by storage and timed by oracle. clock
+// paces KeepAlive's renewal loop; lease expiration itself is always measured
+// against the oracle's time, never clock.
+func NewManager(storage Storage, oracle OracleTimeSource, clock clock.Clock) *Manager {
+	return &Manager{storage: storage, oracle: oracle, clock: clock}
+}
+
+// ErrLeaseNotFound is returned by Revoke, Renew and TimeToLive when no
+// lease with the given ID is currently granted (and by Renew when the lease
+// has already expired).
+var ErrLeaseNotFound = fmt.Errorf("lease not found")
+
+// Grant proposes a new lease with the given ttl, measured from the oracle's
+// current time, and returns its ID.
+func (m *Manager) Grant(ctx context.Context, ttl time.Duration) (ID, error) {
+	now := m.oracle.CorrectedTime()
+	l := Lease{
+		ID:        m.storage.NextID(),
+		TTL:       ttl,
+		GrantedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+	if err := m.storage.ProposeGrant(ctx, l); err != nil {
+		return 0, err
+	}
+	return l.ID, nil
+}
+
+// Revoke proposes removing id immediately, ahead of its natural expiration.
+func (m *Manager) Revoke(ctx context.Context, id ID) error {
+	if _, ok := m.storage.Get(id); !ok {
+		return ErrLeaseNotFound
+	}
+	return m.storage.ProposeRevoke(ctx, id)
+}
+
+// TimeToLive returns how long id has left before it expires, measured
+// against the oracle's current time. A non-positive result means id has
+// already expired but may not yet have been reaped.
+func (m *Manager) TimeToLive(id ID) (time.Duration, error) {
+	l, ok := m.storage.Get(id)
+	if !ok {
+		return 0, ErrLeaseNotFound
+	}
+	return l.ExpiresAt.Sub(m.oracle.CorrectedTime()), nil
+}
+
+// Renew renews id for another full TTL, measured from the oracle's current
+// time, and returns the resulting KeepAliveResponse. It fails with
+// ErrLeaseNotFound if id has already expired or been revoked.
+func (m *Manager) Renew(ctx context.Context, id ID) (*KeepAliveResponse, error) {
+	l, ok := m.storage.Get(id)
+	if !ok {
+		return nil, ErrLeaseNotFound
+	}
+	now := m.oracle.CorrectedTime()
+	if !now.Before(l.ExpiresAt) {
+		return nil, ErrLeaseNotFound
+	}
+	if err := m.storage.ProposeRenew(ctx, id, now.Add(l.TTL)); err != nil {
+		return nil, err
+	}
+	return &KeepAliveResponse{ID: id, TTL: l.TTL}, nil
+}
+
+// KeepAlive renews id every third of its TTL (as measured by m.clock) until
+// ctx is cancelled or id is revoked or expires, sending a
+// KeepAliveResponse on the returned channel after every successful renewal.
+// The channel is closed when KeepAlive stops renewing.
+func (m *Manager) KeepAlive(ctx context.Context, id ID) <-chan *KeepAliveResponse {
+	ch := make(chan *KeepAliveResponse, 1)
+	go func() {
+		defer close(ch)
+		for {
+			resp, err := m.Renew(ctx, id)
+			if err != nil {
+				return
+			}
+			select {
+			case ch <- resp:
+			case <-ctx.Done():
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-m.clock.After(resp.TTL / 3):
+			}
+		}
+	}()
+	return ch
+}
@@ -0,0 +1,205 @@
+package lease
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/elliotcourant/kronos/kronosutil/clock"
+)
+
+// fakeStorage is an in-memory Storage used to test Manager and Expirer
+// without a real raft group.
+type fakeStorage struct {
+	mu     sync.Mutex
+	nextID ID
+	leases map[ID]Lease
+}
+
+func newFakeStorage() *fakeStorage {
+	return &fakeStorage{leases: make(map[ID]Lease)}
+}
+
+func (f *fakeStorage) ProposeGrant(_ context.Context, l Lease) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.leases[l.ID] = l
+	return nil
+}
+
+func (f *fakeStorage) ProposeRevoke(_ context.Context, id ID) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.leases, id)
+	return nil
+}
+
+func (f *fakeStorage) ProposeRenew(_ context.Context, id ID, expiresAt time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	l, ok := f.leases[id]
+	if !ok {
+		return ErrLeaseNotFound
+	}
+	l.ExpiresAt = expiresAt
+	f.leases[id] = l
+	return nil
+}
+
+func (f *fakeStorage) NextID() ID {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.nextID++
+	return f.nextID
+}
+
+func (f *fakeStorage) Get(id ID) (Lease, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	l, ok := f.leases[id]
+	return l, ok
+}
+
+func (f *fakeStorage) All() map[ID]Lease {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out := make(map[ID]Lease, len(f.leases))
+	for k, v := range f.leases {
+		out[k] = v
+	}
+	return out
+}
+
+// fakeOracle adapts a clock.FakeClock to OracleTimeSource.
+type fakeOracle struct {
+	clock *clock.FakeClock
+}
+
+func (f fakeOracle) CorrectedTime() time.Time { return f.clock.Now() }
+
+func TestManagerGrantAndTimeToLive(t *testing.T) {
+	fc := clock.NewFakeClock(time.Unix(0, 0))
+	m := NewManager(newFakeStorage(), fakeOracle{fc}, fc)
+	ctx := context.Background()
+
+	id, err := m.Grant(ctx, 10*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ttl, err := m.TimeToLive(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ttl != 10*time.Second {
+		t.Fatalf("expected 10s ttl, got %v", ttl)
+	}
+
+	fc.Advance(4 * time.Second)
+	ttl, err = m.TimeToLive(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ttl != 6*time.Second {
+		t.Fatalf("expected 6s ttl remaining, got %v", ttl)
+	}
+}
+
+func TestExpirerRevokesAtOracleTime(t *testing.T) {
+	fc := clock.NewFakeClock(time.Unix(0, 0))
+	storage := newFakeStorage()
+	oracle := fakeOracle{fc}
+	m := NewManager(storage, oracle, fc)
+	ctx := context.Background()
+
+	id, err := m.Grant(ctx, 5*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expirer := NewExpirer(storage, oracle, fc)
+	expirer.checkOnce(ctx)
+	if _, ok := storage.Get(id); !ok {
+		t.Fatal("lease should still be live before its ttl elapses")
+	}
+
+	fc.Advance(5 * time.Second)
+	expirer.checkOnce(ctx)
+	if _, ok := storage.Get(id); ok {
+		t.Fatal("lease should have been revoked once the oracle clock passed its expiry")
+	}
+}
+
+func TestExpirerRunTicksAndRevokes(t *testing.T) {
+	fc := clock.NewFakeClock(time.Unix(0, 0))
+	storage := newFakeStorage()
+	oracle := fakeOracle{fc}
+	m := NewManager(storage, oracle, fc)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	id, err := m.Grant(ctx, 5*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expirer := NewExpirer(storage, oracle, fc)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		expirer.Run(ctx)
+	}()
+
+	fc.BlockUntil(1)
+	fc.Advance(expirerTickInterval)
+	if _, ok := storage.Get(id); !ok {
+		t.Fatal("lease should still be live before its ttl elapses")
+	}
+
+	// Run's tick loop processes checkOnce asynchronously, so poll for the
+	// revocation instead of asserting immediately after Advance.
+	fc.Advance(5 * time.Second)
+	deadline := time.After(time.Second)
+	for {
+		if _, ok := storage.Get(id); !ok {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatal("Run should have revoked the lease once the oracle clock passed its expiry")
+		default:
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	cancel()
+	<-done
+}
+
+func TestManagerKeepAliveRenewsUntilCancelled(t *testing.T) {
+	fc := clock.NewFakeClock(time.Unix(0, 0))
+	m := NewManager(newFakeStorage(), fakeOracle{fc}, fc)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	id, err := m.Grant(ctx, 9*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ch := m.KeepAlive(ctx, id)
+	for i := 0; i < 3; i++ {
+		resp := <-ch
+		if resp.ID != id {
+			t.Fatalf("expected keepalive response for lease %d, got %d", id, resp.ID)
+		}
+		fc.BlockUntil(1)
+		fc.Advance(3 * time.Second)
+	}
+
+	cancel()
+	for range ch {
+		// Drain any renewal already in flight when ctx was cancelled; the
+		// channel must still close once KeepAlive notices cancellation.
+	}
+}
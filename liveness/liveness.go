@@ -0,0 +1,178 @@
+// Package liveness tracks which raft members of a Kronos cluster are
+// currently alive, in the style of CockroachDB's NodeLiveness. Every node
+// periodically heartbeats its own Liveness record through raft; any node can
+// cheaply answer IsLive(nodeID) from its local, raft-replicated copy instead
+// of reaching out over the network.
+//
+// Starting NodeLiveness's heartbeat loop and Reaper.Run alongside a node's
+// oracle-management loop is out of scope for this package: that bring-up
+// code lives in the tm/oracle packages, neither of which exists in this
+// checkout (the server package here holds only the admin API). NodeLiveness and Reaper are self-contained and covered by their
+// own tests; they are ready to be started by that loop once it exists.
+package liveness
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/elliotcourant/kronos/alarm"
+	"github.com/elliotcourant/kronos/kronosutil/clock"
+	"github.com/elliotcourant/kronos/kronosutil/log"
+)
+
+const (
+	// HeartbeatInterval is how often a node renews its own Liveness record.
+	HeartbeatInterval = 3 * time.Second
+	// LivenessExpiration is how long a heartbeated Liveness record remains
+	// valid for before a node is considered dead.
+	LivenessExpiration = 9 * time.Second
+	// DefaultDeadNodeThreshold is how long a node's liveness must stay
+	// expired before the reaper proposes removing it from the raft config.
+	DefaultDeadNodeThreshold = 5 * time.Minute
+)
+
+// Liveness is a single node's liveness record, proposed and replicated
+// through the Kronos raft group alongside oracle and metadata state.
+type Liveness struct {
+	NodeID     uint64    `json:"node_id"`
+	Epoch      int64     `json:"epoch"`
+	Expiration time.Time `json:"expiration"`
+}
+
+// isExpired reports whether l was no longer valid at instant now.
+func (l Liveness) isExpired(now time.Time) bool {
+	return now.After(l.Expiration)
+}
+
+// Storage is the raft-backed store of Liveness records that NodeLiveness
+// reads and conditionally updates. It is implemented by the metadata store
+// that already replicates oracle and cluster membership state through raft.
+type Storage interface {
+	// GetLiveness returns the last known Liveness record for nodeID, or
+	// false if none has ever been proposed.
+	GetLiveness(nodeID uint64) (Liveness, bool)
+	// AllLiveness returns every node's last known Liveness record.
+	AllLiveness() map[uint64]Liveness
+	// ConditionalPutLiveness proposes new through raft, succeeding only if
+	// the stored record for new.NodeID still has the given expected epoch.
+	// This is how a node claims the right to extend its own expiration:
+	// incrementing the epoch only succeeds if no one else has done so since
+	// it was last observed, matching a compare-and-swap.
+	ConditionalPutLiveness(ctx context.Context, new Liveness, expectedEpoch int64) error
+	// RemoveNode proposes a raft conf-change removing nodeID from the
+	// cluster.
+	RemoveNode(ctx context.Context, nodeID uint64) error
+}
+
+// NodeLiveness heartbeats the local node's Liveness record and answers
+// IsLive queries from the raft-replicated state in Storage.
+type NodeLiveness struct {
+	nodeID  uint64
+	storage Storage
+	clock   clock.Clock
+	alarms  *alarm.Store
+
+	subscribersMu sync.Mutex
+	subscribers   []chan Liveness
+
+	lastHeartbeat     time.Time
+	haveLastHeartbeat bool
+}
+
+// NewNodeLiveness returns a NodeLiveness for nodeID, heartbeating and reading
+// through storage and timed by clock. Every heartbeat is checked against the
+// previous one with alarm.DetectClockJumpBackward, raising a
+// ClockJumpBackward alarm in alarms if the local clock has gone backward.
+func NewNodeLiveness(nodeID uint64, storage Storage, clock clock.Clock, alarms *alarm.Store) *NodeLiveness {
+	return &NodeLiveness{nodeID: nodeID, storage: storage, clock: clock, alarms: alarms}
+}
+
+// IsLive reports whether nodeID's last known Liveness record had not yet
+// expired as of now.
+func (nl *NodeLiveness) IsLive(nodeID uint64) bool {
+	l, ok := nl.storage.GetLiveness(nodeID)
+	if !ok {
+		return false
+	}
+	return !l.isExpired(nl.clock.Now())
+}
+
+// Subscribe returns a channel on which every Liveness record this
+// NodeLiveness heartbeats for the local node is published; records written
+// by other nodes are not. A record is dropped for a subscriber whose
+// 16-entry buffer is full. The channel is never closed; callers are expected
+// to read it for the lifetime of the node. Subscribe may be called
+// concurrently with Heartbeat/heartbeatOnce publishing to already-registered
+// subscribers.
+func (nl *NodeLiveness) Subscribe() <-chan Liveness {
+	ch := make(chan Liveness, 16)
+	nl.subscribersMu.Lock()
+	defer nl.subscribersMu.Unlock()
+	nl.subscribers = append(nl.subscribers, ch)
+	return ch
+}
+
+func (nl *NodeLiveness) publish(l Liveness) {
+	nl.subscribersMu.Lock()
+	defer nl.subscribersMu.Unlock()
+	for _, sub := range nl.subscribers {
+		select {
+		case sub <- l:
+		default:
+		}
+	}
+}
+
+// heartbeatOnce renews the local node's Liveness record, incrementing its
+// epoch only if the previously observed expiration has already passed; a
+// live node keeps renewing the same epoch.
+func (nl *NodeLiveness) heartbeatOnce(ctx context.Context) error {
+	now := nl.clock.Now()
+	if nl.haveLastHeartbeat {
+		if err := alarm.DetectClockJumpBackward(ctx, nl.alarms, nl.nodeID, nl.lastHeartbeat, now); err != nil {
+			log.Error(ctx, err)
+		}
+	}
+	nl.lastHeartbeat, nl.haveLastHeartbeat = now, true
+
+	prev, ok := nl.storage.GetLiveness(nl.nodeID)
+	next := Liveness{
+		NodeID:     nl.nodeID,
+		Epoch:      1,
+		Expiration: now.Add(LivenessExpiration),
+	}
+	expectedEpoch := int64(0)
+	if ok {
+		expectedEpoch = prev.Epoch
+		next.Epoch = prev.Epoch
+		if prev.isExpired(now) {
+			next.Epoch = prev.Epoch + 1
+		}
+	}
+	if err := nl.storage.ConditionalPutLiveness(ctx, next, expectedEpoch); err != nil {
+		return err
+	}
+	nl.publish(next)
+	return nil
+}
+
+// Heartbeat runs until ctx is cancelled, renewing the local node's Liveness
+// record every HeartbeatInterval (as measured by nl.clock).
+func (nl *NodeLiveness) Heartbeat(ctx context.Context) {
+	ticker := nl.clock.NewTicker(HeartbeatInterval)
+	defer ticker.Stop()
+	if err := nl.heartbeatOnce(ctx); err != nil {
+		log.Error(ctx, err)
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+			if err := nl.heartbeatOnce(ctx); err != nil {
+				log.Error(ctx, err)
+			}
+		}
+	}
+}
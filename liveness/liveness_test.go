@@ -0,0 +1,98 @@
+package liveness
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/elliotcourant/kronos/alarm"
+	"github.com/elliotcourant/kronos/kronosutil/clock"
+)
+
+// fakeAlarmStorage is an in-memory alarm.Storage used to test that
+// NodeLiveness raises alarms without a real raft group.
+type fakeAlarmStorage struct {
+	alarms map[string]alarm.Alarm
+}
+
+func newFakeAlarmStorage() *fakeAlarmStorage {
+	return &fakeAlarmStorage{alarms: make(map[string]alarm.Alarm)}
+}
+
+func (f *fakeAlarmStorage) ProposeRaise(_ context.Context, a alarm.Alarm) error {
+	f.alarms[a.ID] = a
+	return nil
+}
+
+func (f *fakeAlarmStorage) ProposeDisarm(_ context.Context, id string) error {
+	delete(f.alarms, id)
+	return nil
+}
+
+func (f *fakeAlarmStorage) AllAlarms() map[string]alarm.Alarm {
+	out := make(map[string]alarm.Alarm, len(f.alarms))
+	for k, v := range f.alarms {
+		out[k] = v
+	}
+	return out
+}
+
+func TestNodeLivenessRaisesClockJumpBackwardAlarm(t *testing.T) {
+	storage := newFakeStorage()
+	alarmStorage := newFakeAlarmStorage()
+	alarms := alarm.NewStore(alarmStorage)
+	fc := clock.NewFakeClock(time.Unix(100, 0))
+	nl := NewNodeLiveness(1, storage, fc, alarms)
+	ctx := context.Background()
+
+	if err := nl.heartbeatOnce(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if alarms.IsDegraded(1) {
+		t.Fatal("first heartbeat has nothing to compare against and should not raise an alarm")
+	}
+
+	fc.Advance(-10 * time.Second)
+	if err := nl.heartbeatOnce(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if !alarms.IsDegraded(1) {
+		t.Fatal("heartbeat observing the clock move backward should raise a ClockJumpBackward alarm")
+	}
+}
+
+// TestNodeLivenessSubscribeConcurrentWithHeartbeat exercises Subscribe being
+// called concurrently with Heartbeat's own goroutine calling publish, which
+// is the normal way a caller would use these two methods together. Run with
+// -race to catch a data race on subscribers.
+func TestNodeLivenessSubscribeConcurrentWithHeartbeat(t *testing.T) {
+	storage := newFakeStorage()
+	alarms := alarm.NewStore(newFakeAlarmStorage())
+	fc := clock.NewFakeClock(time.Unix(0, 0))
+	nl := NewNodeLiveness(1, storage, fc, alarms)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		nl.Heartbeat(ctx)
+	}()
+
+	var chans []<-chan Liveness
+	for i := 0; i < 10; i++ {
+		fc.BlockUntil(1)
+		chans = append(chans, nl.Subscribe())
+		fc.Advance(HeartbeatInterval)
+	}
+
+	cancel()
+	<-done
+
+	for _, ch := range chans {
+		select {
+		case <-ch:
+		default:
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package liveness
+
+import (
+	"context"
+	"time"
+
+	"github.com/elliotcourant/kronos/kronosutil/clock"
+	"github.com/elliotcourant/kronos/kronosutil/log"
+)
+
+// Reaper watches the cluster's Liveness records and, once it has observed a
+// node expired for at least deadNodeThreshold, proposes a raft conf-change
+// removing it. It is meant to be run as part of the existing
+// oracle-management loop so that a node which never comes back no longer
+// has to be removed by hand.
+type Reaper struct {
+	storage           Storage
+	clock             clock.Clock
+	deadNodeThreshold time.Duration
+
+	// expiredSince tracks, for every node currently observed as expired, the
+	// epoch of its Liveness record and the instant it was first seen expired
+	// at that epoch. A node is only reaped once it has been continuously
+	// expired at the same epoch for at least deadNodeThreshold; if the node
+	// heartbeats again (bumping its epoch) before then, even if it has
+	// already expired again by the time we next check, that counts as
+	// recovery and the timer restarts instead of carrying over the original
+	// first-seen instant.
+	expiredSince map[uint64]expiredRecord
+}
+
+// expiredRecord is the bookkeeping Reaper keeps per node while its liveness
+// is observed expired.
+type expiredRecord struct {
+	epoch     int64
+	firstSeen time.Time
+}
+
+// NewReaper returns a Reaper that removes nodes whose liveness has been
+// expired for at least deadNodeThreshold. A deadNodeThreshold of zero
+// defaults to DefaultDeadNodeThreshold.
+func NewReaper(storage Storage, clock clock.Clock, deadNodeThreshold time.Duration) *Reaper {
+	if deadNodeThreshold == 0 {
+		deadNodeThreshold = DefaultDeadNodeThreshold
+	}
+	return &Reaper{
+		storage:           storage,
+		clock:             clock,
+		deadNodeThreshold: deadNodeThreshold,
+		expiredSince:      make(map[uint64]expiredRecord),
+	}
+}
+
+// checkOnce inspects every known Liveness record and removes nodes that have
+// been continuously expired at the same epoch for at least
+// r.deadNodeThreshold.
+func (r *Reaper) checkOnce(ctx context.Context) {
+	now := r.clock.Now()
+	for nodeID, l := range r.storage.AllLiveness() {
+		if !l.isExpired(now) {
+			delete(r.expiredSince, nodeID)
+			continue
+		}
+		rec, seen := r.expiredSince[nodeID]
+		if !seen || rec.epoch != l.Epoch {
+			// Either the first time we've seen this node expired, or it
+			// heartbeated at a new epoch since we started tracking it (a
+			// flap that has since expired again): restart the clock.
+			r.expiredSince[nodeID] = expiredRecord{epoch: l.Epoch, firstSeen: now}
+			continue
+		}
+		if now.Sub(rec.firstSeen) < r.deadNodeThreshold {
+			continue
+		}
+		if err := r.storage.RemoveNode(ctx, nodeID); err != nil {
+			log.Errorf(ctx, "failed to auto-evict dead node %d: %v", nodeID, err)
+			continue
+		}
+		log.Infof(ctx, "auto-evicted node %d after %s of expired liveness", nodeID, now.Sub(rec.firstSeen))
+		delete(r.expiredSince, nodeID)
+	}
+}
+
+// Run ticks checkOnce every HeartbeatInterval (as measured by r.clock) until
+// ctx is cancelled. It is intended to be started alongside the local node's
+// own Heartbeat loop as part of the oracle-management loop.
+func (r *Reaper) Run(ctx context.Context) {
+	ticker := r.clock.NewTicker(HeartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+			r.checkOnce(ctx)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package liveness
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/elliotcourant/kronos/kronosutil/clock"
+)
+
+// fakeStorage is an in-memory Storage used to test Reaper without a real
+// raft group.
+type fakeStorage struct {
+	records map[uint64]Liveness
+	removed map[uint64]bool
+}
+
+func newFakeStorage() *fakeStorage {
+	return &fakeStorage{records: make(map[uint64]Liveness), removed: make(map[uint64]bool)}
+}
+
+func (f *fakeStorage) GetLiveness(nodeID uint64) (Liveness, bool) {
+	l, ok := f.records[nodeID]
+	return l, ok
+}
+
+func (f *fakeStorage) AllLiveness() map[uint64]Liveness {
+	out := make(map[uint64]Liveness, len(f.records))
+	for k, v := range f.records {
+		out[k] = v
+	}
+	return out
+}
+
+func (f *fakeStorage) ConditionalPutLiveness(_ context.Context, new Liveness, _ int64) error {
+	f.records[new.NodeID] = new
+	return nil
+}
+
+func (f *fakeStorage) RemoveNode(_ context.Context, nodeID uint64) error {
+	f.removed[nodeID] = true
+	delete(f.records, nodeID)
+	return nil
+}
+
+func TestReaperEvictsOnlyAfterDeadNodeThreshold(t *testing.T) {
+	storage := newFakeStorage()
+	fc := clock.NewFakeClock(time.Unix(0, 0))
+	const deadNodeThreshold = time.Minute
+	reaper := NewReaper(storage, fc, deadNodeThreshold)
+
+	storage.records[1] = Liveness{NodeID: 1, Epoch: 1, Expiration: fc.Now().Add(-time.Second)}
+	ctx := context.Background()
+
+	reaper.checkOnce(ctx)
+	if storage.removed[1] {
+		t.Fatal("node should not be evicted before deadNodeThreshold has elapsed")
+	}
+
+	fc.Advance(deadNodeThreshold)
+	reaper.checkOnce(ctx)
+	if !storage.removed[1] {
+		t.Fatal("node should be evicted once deadNodeThreshold has elapsed")
+	}
+}
+
+func TestReaperDoesNotEvictRecoveredNode(t *testing.T) {
+	storage := newFakeStorage()
+	fc := clock.NewFakeClock(time.Unix(0, 0))
+	reaper := NewReaper(storage, fc, time.Minute)
+	ctx := context.Background()
+
+	storage.records[1] = Liveness{NodeID: 1, Epoch: 1, Expiration: fc.Now().Add(-time.Second)}
+	reaper.checkOnce(ctx)
+
+	// Node 1 heartbeats again before the threshold elapses.
+	storage.records[1] = Liveness{NodeID: 1, Epoch: 2, Expiration: fc.Now().Add(LivenessExpiration)}
+	fc.Advance(2 * time.Minute)
+	reaper.checkOnce(ctx)
+
+	if storage.removed[1] {
+		t.Fatal("recovered node should not be evicted")
+	}
+}
@@ -0,0 +1,324 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	kronospb "github.com/elliotcourant/kronos/pb"
+
+	"github.com/elliotcourant/kronos/alarm"
+	"github.com/elliotcourant/kronos/kronosutil/clock"
+	"github.com/elliotcourant/kronos/lease"
+)
+
+// fakeOracleManager is a hand-rolled oracleManager used to exercise AdminMux
+// without standing up a real oracle.Manager/raft group.
+type fakeOracleManager struct {
+	correctedTime time.Time
+	oracle        OracleInfo
+	nodes         []NodeStatus
+	frozen        bool
+	driftCfg      *kronospb.DriftTimeConfig
+	driftErr      error
+}
+
+func (f *fakeOracleManager) CorrectedTime() time.Time   { return f.correctedTime }
+func (f *fakeOracleManager) CurrentOracle() OracleInfo  { return f.oracle }
+func (f *fakeOracleManager) ClusterNodes() []NodeStatus { return f.nodes }
+func (f *fakeOracleManager) Freeze()                    { f.frozen = true }
+func (f *fakeOracleManager) Unfreeze()                  { f.frozen = false }
+func (f *fakeOracleManager) UpdateDriftConfig(cfg *kronospb.DriftTimeConfig) error {
+	if f.driftErr != nil {
+		return f.driftErr
+	}
+	f.driftCfg = cfg
+	return nil
+}
+
+// fakeLeaseStorage is an in-memory lease.Storage, mirroring the fakeStorage
+// used by lease/lease_test.go.
+type fakeLeaseStorage struct {
+	nextID lease.ID
+	leases map[lease.ID]lease.Lease
+}
+
+func newFakeLeaseStorage() *fakeLeaseStorage {
+	return &fakeLeaseStorage{leases: make(map[lease.ID]lease.Lease)}
+}
+
+func (f *fakeLeaseStorage) ProposeGrant(_ context.Context, l lease.Lease) error {
+	f.leases[l.ID] = l
+	return nil
+}
+
+func (f *fakeLeaseStorage) ProposeRevoke(_ context.Context, id lease.ID) error {
+	delete(f.leases, id)
+	return nil
+}
+
+func (f *fakeLeaseStorage) ProposeRenew(_ context.Context, id lease.ID, expiresAt time.Time) error {
+	l, ok := f.leases[id]
+	if !ok {
+		return lease.ErrLeaseNotFound
+	}
+	l.ExpiresAt = expiresAt
+	f.leases[id] = l
+	return nil
+}
+
+func (f *fakeLeaseStorage) NextID() lease.ID {
+	f.nextID++
+	return f.nextID
+}
+
+func (f *fakeLeaseStorage) Get(id lease.ID) (lease.Lease, bool) {
+	l, ok := f.leases[id]
+	return l, ok
+}
+
+func (f *fakeLeaseStorage) All() map[lease.ID]lease.Lease {
+	out := make(map[lease.ID]lease.Lease, len(f.leases))
+	for k, v := range f.leases {
+		out[k] = v
+	}
+	return out
+}
+
+// fakeAlarmStorage is an in-memory alarm.Storage, mirroring the fakeStorage
+// used by alarm/detect_test.go.
+type fakeAlarmStorage struct {
+	raised map[string]alarm.Alarm
+}
+
+func newFakeAlarmStorage() *fakeAlarmStorage {
+	return &fakeAlarmStorage{raised: make(map[string]alarm.Alarm)}
+}
+
+func (f *fakeAlarmStorage) ProposeRaise(_ context.Context, a alarm.Alarm) error {
+	f.raised[a.ID] = a
+	return nil
+}
+
+func (f *fakeAlarmStorage) ProposeDisarm(_ context.Context, id string) error {
+	delete(f.raised, id)
+	return nil
+}
+
+func (f *fakeAlarmStorage) AllAlarms() map[string]alarm.Alarm {
+	return f.raised
+}
+
+// newTestServer returns a Server backed entirely by fakes, and the
+// fakeOracleManager so tests can assert against/mutate its state directly.
+func newTestServer() (*Server, *fakeOracleManager) {
+	oracle := &fakeOracleManager{correctedTime: time.Unix(100, 0)}
+	alarms := alarm.NewStore(newFakeAlarmStorage())
+	leases := lease.NewManager(newFakeLeaseStorage(), oracle, clock.NewClock())
+	return New(1, oracle, alarms, leases), oracle
+}
+
+func TestHandleTime(t *testing.T) {
+	s, oracle := newTestServer()
+	req := httptest.NewRequest(http.MethodGet, "/v1/time", nil)
+	w := httptest.NewRecorder()
+
+	s.AdminMux().ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var resp timeResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.OracleTime != oracle.correctedTime.UnixNano() {
+		t.Fatalf("expected oracle time %d, got %d", oracle.correctedTime.UnixNano(), resp.OracleTime)
+	}
+}
+
+func TestHandleTimeRefusesWhenDegraded(t *testing.T) {
+	s, _ := newTestServer()
+	if err := s.alarms.Raise(context.Background(), alarm.ClockJumpBackward, s.NodeID, "test"); err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(http.MethodGet, "/v1/time", nil)
+	w := httptest.NewRecorder()
+
+	s.AdminMux().ServeHTTP(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 for a degraded node, got %d", w.Code)
+	}
+}
+
+func TestHandleOracle(t *testing.T) {
+	s, oracle := newTestServer()
+	oracle.oracle = OracleInfo{NodeID: 1, Epoch: 7}
+	req := httptest.NewRequest(http.MethodGet, "/v1/oracle", nil)
+	w := httptest.NewRecorder()
+
+	s.AdminMux().ServeHTTP(w, req)
+
+	var resp OracleInfo
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp != oracle.oracle {
+		t.Fatalf("expected %+v, got %+v", oracle.oracle, resp)
+	}
+}
+
+func TestHandleClusterFreeze(t *testing.T) {
+	s, oracle := newTestServer()
+
+	body, _ := json.Marshal(clusterFreezeRequest{})
+	req := httptest.NewRequest(http.MethodPost, "/v1/cluster/freeze", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	s.AdminMux().ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	if !oracle.frozen {
+		t.Fatal("expected Freeze to have been called")
+	}
+
+	body, _ = json.Marshal(clusterFreezeRequest{Unfreeze: true})
+	req = httptest.NewRequest(http.MethodPost, "/v1/cluster/freeze", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	s.AdminMux().ServeHTTP(w, req)
+	if oracle.frozen {
+		t.Fatal("expected Unfreeze to have been called")
+	}
+}
+
+func TestHandleClusterFreezeRejectsGet(t *testing.T) {
+	s, _ := newTestServer()
+	req := httptest.NewRequest(http.MethodGet, "/v1/cluster/freeze", nil)
+	w := httptest.NewRecorder()
+	s.AdminMux().ServeHTTP(w, req)
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405, got %d", w.Code)
+	}
+}
+
+func TestHandleDrift(t *testing.T) {
+	s, oracle := newTestServer()
+
+	cfg := kronospb.DriftTimeConfig{DriftFactor: 1.5}
+	body, _ := json.Marshal(cfg)
+	req := httptest.NewRequest(http.MethodPost, "/v1/drift", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	s.AdminMux().ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	if oracle.driftCfg == nil || oracle.driftCfg.DriftFactor != cfg.DriftFactor {
+		t.Fatalf("expected UpdateDriftConfig to be called with %+v, got %+v", cfg, oracle.driftCfg)
+	}
+}
+
+func TestHandleLeaseGrantTTLRevoke(t *testing.T) {
+	s, _ := newTestServer()
+
+	grantBody, _ := json.Marshal(leaseGrantRequest{TTL: 10 * time.Second})
+	req := httptest.NewRequest(http.MethodPost, "/v1/lease/grant", bytes.NewReader(grantBody))
+	w := httptest.NewRecorder()
+	s.AdminMux().ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var grantResp leaseGrantResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &grantResp); err != nil {
+		t.Fatal(err)
+	}
+
+	ttlBody, _ := json.Marshal(leaseIDRequest{ID: grantResp.ID})
+	req = httptest.NewRequest(http.MethodPost, "/v1/lease/ttl", bytes.NewReader(ttlBody))
+	w = httptest.NewRecorder()
+	s.AdminMux().ServeHTTP(w, req)
+	var ttlResp leaseTTLResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &ttlResp); err != nil {
+		t.Fatal(err)
+	}
+	if ttlResp.TTL != 10*time.Second {
+		t.Fatalf("expected 10s ttl, got %v", ttlResp.TTL)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/v1/lease/revoke", bytes.NewReader(ttlBody))
+	w = httptest.NewRecorder()
+	s.AdminMux().ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/v1/lease/ttl", bytes.NewReader(ttlBody))
+	w = httptest.NewRecorder()
+	s.AdminMux().ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for a revoked lease, got %d", w.Code)
+	}
+}
+
+func TestHandleLeaseKeepAlive(t *testing.T) {
+	s, _ := newTestServer()
+
+	grantBody, _ := json.Marshal(leaseGrantRequest{TTL: 10 * time.Second})
+	req := httptest.NewRequest(http.MethodPost, "/v1/lease/grant", bytes.NewReader(grantBody))
+	w := httptest.NewRecorder()
+	s.AdminMux().ServeHTTP(w, req)
+	var grantResp leaseGrantResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &grantResp); err != nil {
+		t.Fatal(err)
+	}
+
+	idBody, _ := json.Marshal(leaseIDRequest{ID: grantResp.ID})
+	req = httptest.NewRequest(http.MethodPost, "/v1/lease/keepalive", bytes.NewReader(idBody))
+	w = httptest.NewRecorder()
+	s.AdminMux().ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var resp lease.KeepAliveResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.ID != grantResp.ID {
+		t.Fatalf("expected keepalive response for lease %d, got %d", grantResp.ID, resp.ID)
+	}
+}
+
+func TestHandleAlarmsListAndDisarm(t *testing.T) {
+	s, _ := newTestServer()
+	if err := s.alarms.Raise(context.Background(), alarm.ClockJumpBackward, s.NodeID, "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/alarms", nil)
+	w := httptest.NewRecorder()
+	s.AdminMux().ServeHTTP(w, req)
+	var alarms []alarm.Alarm
+	if err := json.Unmarshal(w.Body.Bytes(), &alarms); err != nil {
+		t.Fatal(err)
+	}
+	if len(alarms) != 1 {
+		t.Fatalf("expected 1 active alarm, got %d", len(alarms))
+	}
+
+	disarmBody, _ := json.Marshal(alarmsDisarmRequest{ID: alarms[0].ID})
+	req = httptest.NewRequest(http.MethodPost, "/v1/alarms/disarm", bytes.NewReader(disarmBody))
+	w = httptest.NewRecorder()
+	s.AdminMux().ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	if s.alarms.IsDegraded(s.NodeID) {
+		t.Fatal("expected node to no longer be degraded after disarm")
+	}
+}
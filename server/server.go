@@ -0,0 +1,84 @@
+// Package server holds a Kronos node's HTTP admin/inspection surface. It
+// does not contain a gRPC time service.
+package server
+
+import (
+	"net/http"
+	"time"
+
+	kronospb "github.com/elliotcourant/kronos/pb"
+
+	"github.com/elliotcourant/kronos/alarm"
+	"github.com/elliotcourant/kronos/lease"
+)
+
+// OracleInfo describes the current oracle as seen by a single node.
+type OracleInfo struct {
+	NodeID          uint64    `json:"node_id"`
+	Epoch           int64     `json:"epoch"`
+	LastElectedTime time.Time `json:"last_elected_time"`
+}
+
+// NodeStatus describes one raft member as seen by the local node, for the
+// cluster/nodes admin endpoint.
+type NodeStatus struct {
+	NodeID   uint64    `json:"node_id"`
+	Address  string    `json:"address"`
+	IsLive   bool      `json:"is_live"`
+	LastSeen time.Time `json:"last_seen"`
+}
+
+// oracleManager is the subset of oracle.Manager that the admin API depends
+// on. It is expressed as an interface, rather than importing oracle.Manager
+// directly, so that this package stays testable without standing up a real
+// raft group.
+type oracleManager interface {
+	// CorrectedTime returns the oracle-corrected time as seen by this node.
+	CorrectedTime() time.Time
+	CurrentOracle() OracleInfo
+	ClusterNodes() []NodeStatus
+	// Freeze halts oracle re-election and blocks new time updates so an
+	// operator can safely take a backup without first stopping the node.
+	Freeze()
+	// Unfreeze reverses Freeze.
+	Unfreeze()
+	// UpdateDriftConfig adjusts the node's drift configuration at runtime.
+	UpdateDriftConfig(cfg *kronospb.DriftTimeConfig) error
+}
+
+// Server is a single Kronos node's runtime state, served by the HTTP admin
+// API.
+type Server struct {
+	NodeID uint64
+	oracle oracleManager
+	alarms *alarm.Store
+	leases *lease.Manager
+}
+
+// New returns a Server for the given node backed by the given oracle
+// manager, alarm store and lease manager.
+func New(nodeID uint64, oracle oracleManager, alarms *alarm.Store, leases *lease.Manager) *Server {
+	return &Server{NodeID: nodeID, oracle: oracle, alarms: alarms, leases: leases}
+}
+
+// ListenAndServeAdmin serves AdminMux on addr, blocking until the listener
+// fails or is closed. It is the entry point a node's startup code is
+// expected to call alongside the gRPC time service; no such startup code
+// exists in this checkout (there is no "kronos start" command here), so
+// nothing calls this yet, but AdminMux itself needs a real listener rather
+// than only being reachable from tests.
+func (s *Server) ListenAndServeAdmin(addr string) error {
+	return http.ListenAndServe(addr, s.AdminMux())
+}
+
+// ErrDegraded is the error GET /v1/time reports (with status 503) when the
+// local node has an active alarm; no gRPC Time RPC exists in this package to
+// return it. Serving a corrected time while the alarm subsystem believes the
+// clock may be pathological would defeat the point of raising the alarm.
+var ErrDegraded = &degradedError{}
+
+type degradedError struct{}
+
+func (*degradedError) Error() string {
+	return "node is degraded by an active alarm and cannot serve time requests"
+}